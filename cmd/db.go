@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmelahman/work/database"
+	"github.com/spf13/cobra"
+)
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Manage the work database",
+}
+
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply any pending schema migrations",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dal, err := database.NewWorkDAL("")
+		if err != nil {
+			return err
+		}
+		return dal.Migrate(context.Background())
+	},
+}
+
+var dbVersionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the current schema version",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dal, err := database.NewWorkDAL("")
+		if err != nil {
+			return err
+		}
+		version, err := dal.SchemaVersion(context.Background())
+		if err != nil {
+			return err
+		}
+		fmt.Println(version)
+		return nil
+	},
+}
+
+func init() {
+	dbCmd.AddCommand(dbMigrateCmd)
+	dbCmd.AddCommand(dbVersionCmd)
+	rootCmd.AddCommand(dbCmd)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jmelahman/work/database"
+	"github.com/jmelahman/work/database/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat string
+	importFormat string
+	importDryRun bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export tasks to JSON or CSV",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dal, err := database.NewWorkDAL("")
+		if err != nil {
+			return err
+		}
+		return dal.Export(context.Background(), os.Stdout, exportFormat, models.TaskFilter{})
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import tasks from JSON or CSV",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dal, err := database.NewWorkDAL("")
+		if err != nil {
+			return err
+		}
+
+		report, err := dal.Import(context.Background(), os.Stdin, importFormat, models.ImportOptions{DryRun: importDryRun})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("inserted %d, skipped %d, errored %d\n", report.Inserted, report.Skipped, report.Errored)
+		for _, msg := range report.Errors {
+			fmt.Fprintln(os.Stderr, msg)
+		}
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportFormat, "format", database.FormatJSON, "export format: json or csv")
+	importCmd.Flags().StringVar(&importFormat, "format", database.FormatJSON, "import format: json or csv")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "validate and de-duplicate without writing")
+
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jmelahman/work/database"
+	"github.com/jmelahman/work/database/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportWeekly  bool
+	reportMonthly bool
+	reportFormat  string
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarize tracked time",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if reportWeekly && reportMonthly {
+			return fmt.Errorf("--weekly and --monthly are mutually exclusive")
+		}
+
+		dal, err := database.NewWorkDAL("")
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+
+		if reportMonthly {
+			now := time.Now().UTC()
+			from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+			to := from.AddDate(0, 1, 0)
+
+			totals, err := dal.SummaryByProject(ctx, from, to)
+			if err != nil {
+				return err
+			}
+			return printProjectTotals(reportFormat, totals)
+		}
+
+		sheet, err := dal.Timesheet(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+		return printTimesheet(reportFormat, sheet)
+	},
+}
+
+func init() {
+	reportCmd.Flags().BoolVar(&reportWeekly, "weekly", false, "report billable vs non-billable hours per day this week (default)")
+	reportCmd.Flags().BoolVar(&reportMonthly, "monthly", false, "report hours by project this month")
+	reportCmd.Flags().StringVar(&reportFormat, "format", "table", "output format: table, json, or csv")
+
+	rootCmd.AddCommand(reportCmd)
+}
+
+func hours(seconds int64) float64 {
+	return float64(seconds) / 3600
+}
+
+func printTimesheet(format string, sheet []models.DayTimesheet) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(sheet)
+
+	case "csv":
+		writer := csv.NewWriter(os.Stdout)
+		if err := writer.Write([]string{"date", "billable_hours", "non_billable_hours"}); err != nil {
+			return err
+		}
+		for _, day := range sheet {
+			if err := writer.Write([]string{
+				day.Date,
+				fmt.Sprintf("%.2f", hours(day.BillableSeconds)),
+				fmt.Sprintf("%.2f", hours(day.NonBillableSeconds)),
+			}); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+
+	case "table":
+		fmt.Printf("%-12s%-16s%-16s\n", "DATE", "BILLABLE", "NON-BILLABLE")
+		for _, day := range sheet {
+			fmt.Printf("%-12s%-16.2f%-16.2f\n", day.Date, hours(day.BillableSeconds), hours(day.NonBillableSeconds))
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported report format %q", format)
+	}
+}
+
+func printProjectTotals(format string, totals []models.ProjectTotals) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(totals)
+
+	case "csv":
+		writer := csv.NewWriter(os.Stdout)
+		if err := writer.Write([]string{"project", "hours"}); err != nil {
+			return err
+		}
+		for _, t := range totals {
+			if err := writer.Write([]string{t.Project, fmt.Sprintf("%.2f", hours(t.Seconds))}); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+
+	case "table":
+		fmt.Printf("%-24s%-10s\n", "PROJECT", "HOURS")
+		for _, t := range totals {
+			fmt.Printf("%-24s%-10.2f\n", t.Project, hours(t.Seconds))
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported report format %q", format)
+	}
+}
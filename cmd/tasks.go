@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmelahman/work/database"
+	"github.com/jmelahman/work/database/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tasksProject string
+	tasksTags    []string
+	tasksSince   string
+	tasksUntil   string
+	tasksGrep    string
+)
+
+var tasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "Work with tracked tasks",
+}
+
+var tasksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tasks matching a set of filters",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter := models.TaskFilter{
+			Project:             tasksProject,
+			Tags:                tasksTags,
+			DescriptionContains: tasksGrep,
+		}
+
+		if tasksSince != "" {
+			since, err := time.Parse(time.DateOnly, tasksSince)
+			if err != nil {
+				return fmt.Errorf("invalid --since: %v", err)
+			}
+			filter.From = since
+		}
+		if tasksUntil != "" {
+			until, err := time.Parse(time.DateOnly, tasksUntil)
+			if err != nil {
+				return fmt.Errorf("invalid --until: %v", err)
+			}
+			// filter.To is an exclusive upper bound, so include the whole
+			// of the requested day by bounding at the start of the next one.
+			filter.To = until.AddDate(0, 0, 1)
+		}
+
+		dal, err := database.NewWorkDAL("")
+		if err != nil {
+			return err
+		}
+
+		tasks, err := dal.QueryTasks(context.Background(), filter)
+		if err != nil {
+			return err
+		}
+
+		for _, task := range tasks {
+			fmt.Printf("%d\t%s\t%s\t%s\n", task.ID, task.Project, task.Description, task.Start.Format(time.DateTime))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	tasksListCmd.Flags().StringVar(&tasksProject, "project", "", "filter by project name")
+	tasksListCmd.Flags().StringSliceVar(&tasksTags, "tag", nil, "filter by tag (repeatable)")
+	tasksListCmd.Flags().StringVar(&tasksSince, "since", "", "only tasks starting on or after this date (YYYY-MM-DD)")
+	tasksListCmd.Flags().StringVar(&tasksUntil, "until", "", "only tasks starting on or before this date (YYYY-MM-DD)")
+	tasksListCmd.Flags().StringVar(&tasksGrep, "grep", "", "only tasks whose description contains this substring")
+
+	tasksCmd.AddCommand(tasksListCmd)
+	rootCmd.AddCommand(tasksCmd)
+}
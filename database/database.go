@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
@@ -62,43 +63,111 @@ func NewWorkDAL(databasePath string) (*WorkDAL, error) {
 		return nil, err
 	}
 
+	// modernc.org/sqlite serializes writers internally, and SQLite itself
+	// only allows one writer at a time; capping the pool at one connection
+	// avoids "database is locked" errors from concurrent Go-level
+	// connections racing each other instead of queuing through busy_timeout.
+	db.SetMaxOpenConns(1)
+
 	dal := &WorkDAL{db: db}
 
-	_, err = dal.db.Exec(`CREATE TABLE IF NOT EXISTS shift (id INTEGER PRIMARY KEY, start TIME, end TIME)`)
-	if err != nil {
+	ctx := context.Background()
+	if _, err := dal.db.ExecContext(ctx, `PRAGMA journal_mode=WAL`); err != nil {
 		return nil, err
 	}
-	_, err = dal.db.Exec(`CREATE TABLE IF NOT EXISTS task (id INTEGER PRIMARY KEY, description TEXT, classification INT, start TIME, end TIME)`)
-	if err != nil {
+	if _, err := dal.db.ExecContext(ctx, `PRAGMA busy_timeout=5000`); err != nil {
 		return nil, err
 	}
+
+	if err := dal.Migrate(ctx); err != nil {
+		return nil, err
+	}
+
 	return dal, nil
 }
 
-func (dal *WorkDAL) CreateTask(task models.Task) error {
-	_, err := dal.db.Exec(`INSERT INTO task (id, description, classification, start, end) VALUES (?, ?, ?, ?, ?)`,
+// WithTx runs fn inside a single database transaction, committing if fn
+// returns nil and rolling back otherwise. Use it to group multiple DAL
+// calls (e.g. ending one task and starting another) into one atomic unit.
+func (dal *WorkDAL) WithTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := dal.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (dal *WorkDAL) CreateTask(ctx context.Context, task models.Task) error {
+	return dal.WithTx(ctx, func(tx *sql.Tx) error {
+		return createTaskTx(ctx, tx, task)
+	})
+}
+
+func createTaskTx(ctx context.Context, tx *sql.Tx, task models.Task) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO task (id, description, classification, project, start, end) VALUES (?, ?, ?, ?, ?, ?)`,
 		task.ID,
 		task.Description,
 		task.Classification,
-		task.Start.Format(time.UnixDate),
-		task.End.Format(time.UnixDate),
+		task.Project,
+		task.Start.Unix(),
+		unixOrNull(task.End),
 	)
 	if err != nil {
 		return err
 	}
+
+	for _, tag := range task.Tags {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO task_tag (task_id, tag) VALUES (?, ?)`, task.ID, tag); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (dal *WorkDAL) EndTask(id int) error {
-	_, err := dal.db.Exec(`UPDATE task SET end=? WHERE id=?`, time.Now().Format(time.UnixDate), id)
+func (dal *WorkDAL) EndTask(ctx context.Context, id int) error {
+	return dal.WithTx(ctx, func(tx *sql.Tx) error {
+		return endTaskTx(ctx, tx, id)
+	})
+}
+
+func endTaskTx(ctx context.Context, tx *sql.Tx, id int) error {
+	_, err := tx.ExecContext(ctx, `UPDATE task SET end=? WHERE id=?`, time.Now().Unix(), id)
 	if err != nil {
 		return fmt.Errorf("error closing previous task: %v", err)
 	}
 	return nil
 }
 
-func (dal *WorkDAL) GetLatestTask() (models.Task, error) {
-	tasks, err := dal.ListTasks(1, 0)
+// SwitchTask ends the currently running task, if any, and starts task, all
+// within a single transaction so a failure partway through never leaves two
+// tasks open at once.
+func (dal *WorkDAL) SwitchTask(ctx context.Context, task models.Task) error {
+	return dal.WithTx(ctx, func(tx *sql.Tx) error {
+		current, err := latestTaskTx(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if current.ID != 0 && current.End.IsZero() {
+			if err := endTaskTx(ctx, tx, current.ID); err != nil {
+				return err
+			}
+		}
+		return createTaskTx(ctx, tx, task)
+	})
+}
+
+// GetLatestTask returns the task with the highest ID. Callers can check
+// task.End.IsZero() to tell whether it is still running, since an open task
+// is now stored with a NULL end rather than a sentinel timestamp.
+func (dal *WorkDAL) GetLatestTask(ctx context.Context) (models.Task, error) {
+	tasks, err := dal.ListTasks(ctx, 1, 0)
 	if err != nil {
 		return models.Task{}, err
 	}
@@ -108,69 +177,49 @@ func (dal *WorkDAL) GetLatestTask() (models.Task, error) {
 	return tasks[0], nil
 }
 
-func (dal *WorkDAL) ListTasks(limit int, days int) ([]models.Task, error) {
-	tasks := []models.Task{}
-
-	query := `SELECT id, description, classification, start, end FROM task`
-	args := []interface{}{}
-
-	if days > 0 {
-		query += ` WHERE start > datetime('now', '-' || ? || ' days')`
-		args = append(args, days)
+func latestTaskTx(ctx context.Context, tx *sql.Tx) (models.Task, error) {
+	var (
+		id             int
+		description    string
+		classification models.TaskClassification
+		project        string
+		start          int64
+		end            sql.NullInt64
+	)
+	row := tx.QueryRowContext(ctx, `SELECT id, description, classification, project, start, end FROM task ORDER BY id DESC LIMIT 1`)
+	if err := row.Scan(&id, &description, &classification, &project, &start, &end); err != nil {
+		if err == sql.ErrNoRows {
+			return models.Task{}, nil
+		}
+		return models.Task{}, err
 	}
+	return models.Task{
+		ID:             id,
+		Description:    description,
+		Classification: classification,
+		Project:        project,
+		Start:          time.Unix(start, 0),
+		End:            unixOrZero(end),
+	}, nil
+}
 
-	query += ` ORDER BY id DESC`
-
-	if limit > 0 {
-		query += ` LIMIT ?`
-		args = append(args, limit)
+// ListTasks is a convenience wrapper around QueryTasks for the common case
+// of "the last N tasks, optionally limited to the past `days` days". Callers
+// needing richer filtering (project, tags, classification, ...) should use
+// QueryTasks directly.
+func (dal *WorkDAL) ListTasks(ctx context.Context, limit int, days int) ([]models.Task, error) {
+	filter := models.TaskFilter{Limit: limit}
+	if days > 0 {
+		filter.From = time.Now().AddDate(0, 0, -days)
 	}
+	return dal.QueryTasks(ctx, filter)
+}
 
-	rows, err := dal.db.Query(query, args...)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var (
-			id             int
-			description    string
-			classification models.TaskClassification
-			start          string
-			end            string
-		)
-		err := rows.Scan(&id, &description, &classification, &start, &end)
-		if err != nil {
-			return nil, err
-		}
-		startTime, err := time.Parse(time.UnixDate, start)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse start time: %v", err)
-		}
-		endTime, err := time.Parse(time.UnixDate, end)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse end time: %v", err)
-		}
-		tasks = append(
-			tasks,
-			models.Task{
-				ID:             id,
-				Description:    description,
-				Classification: classification,
-				Start:          startTime,
-				End:            endTime,
-			},
-		)
-	}
-	return tasks, nil
-}
-
-func (dal *WorkDAL) CreateShift(shift models.Shift) error {
-	_, err := dal.db.Exec(`INSERT INTO shift (id, start, end) VALUES (?, ?, ?)`,
+func (dal *WorkDAL) CreateShift(ctx context.Context, shift models.Shift) error {
+	_, err := dal.db.ExecContext(ctx, `INSERT INTO shift (id, start, end) VALUES (?, ?, ?)`,
 		shift.ID,
-		shift.Start.Format(time.UnixDate),
-		shift.End.Format(time.UnixDate),
+		shift.Start.Unix(),
+		unixOrNull(shift.End),
 	)
 	if err != nil {
 		return err
@@ -178,16 +227,16 @@ func (dal *WorkDAL) CreateShift(shift models.Shift) error {
 	return nil
 }
 
-func (dal *WorkDAL) EndShift(id int) error {
-	_, err := dal.db.Exec(`UPDATE shift SET end=? WHERE id=?`, time.Now().Format(time.UnixDate), id)
+func (dal *WorkDAL) EndShift(ctx context.Context, id int) error {
+	_, err := dal.db.ExecContext(ctx, `UPDATE shift SET end=? WHERE id=?`, time.Now().Unix(), id)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (dal *WorkDAL) GetLatestShift() (models.Shift, error) {
-	shifts, err := dal.ListShifts(1, 0)
+func (dal *WorkDAL) GetLatestShift(ctx context.Context) (models.Shift, error) {
+	shifts, err := dal.ListShifts(ctx, 1, 0)
 	if err != nil {
 		return models.Shift{}, err
 	}
@@ -197,48 +246,12 @@ func (dal *WorkDAL) GetLatestShift() (models.Shift, error) {
 	return shifts[0], nil
 }
 
-func (dal *WorkDAL) ListShifts(limit int, days int) ([]models.Shift, error) {
-	shifts := []models.Shift{}
-
-	query := `SELECT id, start, end FROM shift`
-	args := []interface{}{}
-
+// ListShifts is a convenience wrapper around QueryShifts for the common case
+// of "the last N shifts, optionally limited to the past `days` days".
+func (dal *WorkDAL) ListShifts(ctx context.Context, limit int, days int) ([]models.Shift, error) {
+	filter := models.ShiftFilter{Limit: limit}
 	if days > 0 {
-		query += ` WHERE start > datetime('now', '-' || ? || ' days')`
-		args = append(args, days)
-	}
-
-	query += ` ORDER BY id DESC`
-
-	if limit > 0 {
-		query += ` LIMIT ?`
-		args = append(args, limit)
-	}
-
-	rows, err := dal.db.Query(query, args...)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var (
-			id    int
-			start string
-			end   string
-		)
-		if err = rows.Scan(&id, &start, &end); err != nil {
-			return nil, err
-		}
-		startTime, err := time.Parse(time.UnixDate, start)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse start time: %v", err)
-		}
-		endTime, err := time.Parse(time.UnixDate, end)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse end time: %v", err)
-		}
-		shifts = append(shifts, models.Shift{ID: id, Start: startTime, End: endTime})
+		filter.From = time.Now().AddDate(0, 0, -days)
 	}
-	return shifts, nil
+	return dal.QueryShifts(ctx, filter)
 }
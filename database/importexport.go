@@ -0,0 +1,256 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmelahman/work/database/models"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// taskRecord is the on-disk shape of a task in both the JSON and CSV export
+// formats. Timestamps are RFC 3339 so exports are portable across machines
+// and locales; an open task's end is the empty string.
+type taskRecord struct {
+	ID             int    `json:"id"`
+	Description    string `json:"description"`
+	Classification string `json:"classification"`
+	Project        string `json:"project"`
+	Tags           string `json:"tags"`
+	Start          string `json:"start"`
+	End            string `json:"end"`
+}
+
+func taskToRecord(task models.Task) taskRecord {
+	var end string
+	if !task.End.IsZero() {
+		end = task.End.UTC().Format(time.RFC3339)
+	}
+	return taskRecord{
+		ID:             task.ID,
+		Description:    task.Description,
+		Classification: task.Classification.String(),
+		Project:        task.Project,
+		Tags:           strings.Join(task.Tags, ";"),
+		Start:          task.Start.UTC().Format(time.RFC3339),
+		End:            end,
+	}
+}
+
+func (r taskRecord) toTask() (models.Task, error) {
+	classification, err := models.ParseTaskClassification(r.Classification)
+	if err != nil {
+		return models.Task{}, err
+	}
+
+	start, err := time.Parse(time.RFC3339, r.Start)
+	if err != nil {
+		return models.Task{}, fmt.Errorf("invalid start time %q: %v", r.Start, err)
+	}
+
+	var end time.Time
+	if r.End != "" {
+		end, err = time.Parse(time.RFC3339, r.End)
+		if err != nil {
+			return models.Task{}, fmt.Errorf("invalid end time %q: %v", r.End, err)
+		}
+	}
+
+	var tags []string
+	if r.Tags != "" {
+		tags = strings.Split(r.Tags, ";")
+	}
+
+	return models.Task{
+		ID:             r.ID,
+		Description:    r.Description,
+		Classification: classification,
+		Project:        r.Project,
+		Tags:           tags,
+		Start:          start,
+		End:            end,
+	}, nil
+}
+
+var taskRecordHeader = []string{"id", "description", "classification", "project", "tags", "start", "end"}
+
+// Export writes every task matching filter to w in the requested format
+// ("json" or "csv").
+func (dal *WorkDAL) Export(ctx context.Context, w io.Writer, format string, filter models.TaskFilter) error {
+	tasks, err := dal.QueryTasks(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatJSON:
+		records := make([]taskRecord, len(tasks))
+		for i, task := range tasks {
+			records[i] = taskToRecord(task)
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(taskRecordHeader); err != nil {
+			return err
+		}
+		for _, task := range tasks {
+			r := taskToRecord(task)
+			if err := writer.Write([]string{
+				strconv.Itoa(r.ID), r.Description, r.Classification, r.Project, r.Tags, r.Start, r.End,
+			}); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// Import reads tasks from r in the requested format ("json" or "csv") and
+// inserts them, skipping rows that duplicate an existing task by ID or by
+// (start, description). The whole import is one transaction: the first row
+// that fails aborts the import and rolls back every insert made so far,
+// returning the partial report alongside the error that caused the abort.
+func (dal *WorkDAL) Import(ctx context.Context, r io.Reader, format string, opts models.ImportOptions) (models.ImportReport, error) {
+	records, err := decodeTaskRecords(r, format)
+	if err != nil {
+		return models.ImportReport{}, err
+	}
+
+	report := models.ImportReport{}
+
+	tx, err := dal.db.BeginTx(ctx, nil)
+	if err != nil {
+		return report, err
+	}
+	defer tx.Rollback()
+
+	for _, record := range records {
+		task, err := record.toTask()
+		if err != nil {
+			report.Errored++
+			report.Errors = append(report.Errors, err.Error())
+			return report, err
+		}
+
+		exists, err := taskExists(ctx, tx, task)
+		if err != nil {
+			report.Errored++
+			report.Errors = append(report.Errors, err.Error())
+			return report, err
+		}
+		if exists {
+			report.Skipped++
+			continue
+		}
+
+		if opts.DryRun {
+			report.Inserted++
+			continue
+		}
+
+		if _, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO task (id, description, classification, project, start, end) VALUES (?, ?, ?, ?, ?, ?)`,
+			task.ID, task.Description, task.Classification, task.Project, task.Start.Unix(), unixOrNull(task.End),
+		); err != nil {
+			report.Errored++
+			report.Errors = append(report.Errors, err.Error())
+			return report, err
+		}
+		for _, tag := range task.Tags {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO task_tag (task_id, tag) VALUES (?, ?)`, task.ID, tag); err != nil {
+				report.Errored++
+				report.Errors = append(report.Errors, err.Error())
+				return report, err
+			}
+		}
+		report.Inserted++
+	}
+
+	if opts.DryRun {
+		return report, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.ImportReport{}, err
+	}
+	return report, nil
+}
+
+// taskExists reports whether task would duplicate a row already in the
+// database: either the same ID, or the same (start, description) pair.
+func taskExists(ctx context.Context, tx *sql.Tx, task models.Task) (bool, error) {
+	var count int
+	row := tx.QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM task WHERE id = ? OR (start = ? AND description = ?)`,
+		task.ID, task.Start.Unix(), task.Description,
+	)
+	if err := row.Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func decodeTaskRecords(r io.Reader, format string) ([]taskRecord, error) {
+	switch format {
+	case FormatJSON:
+		var records []taskRecord
+		if err := json.NewDecoder(r).Decode(&records); err != nil {
+			return nil, err
+		}
+		return records, nil
+
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			return nil, nil
+		}
+		records := make([]taskRecord, 0, len(rows)-1)
+		for _, row := range rows[1:] {
+			if len(row) != len(taskRecordHeader) {
+				return nil, fmt.Errorf("expected %d CSV columns, got %d", len(taskRecordHeader), len(row))
+			}
+			id, err := strconv.Atoi(row[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid id %q: %v", row[0], err)
+			}
+			records = append(records, taskRecord{
+				ID:             id,
+				Description:    row[1],
+				Classification: row[2],
+				Project:        row[3],
+				Tags:           row[4],
+				Start:          row[5],
+				End:            row[6],
+			})
+		}
+		return records, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported import format %q", format)
+	}
+}
@@ -0,0 +1,72 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmelahman/work/database/models"
+)
+
+func TestExportImportRoundTrip(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "database.db")
+	dal, err := NewWorkDAL(dbPath)
+	if err != nil {
+		t.Fatalf("NewWorkDAL() returned error: %v", err)
+	}
+
+	task := models.Task{
+		ID:             1,
+		Description:    "write docs",
+		Classification: models.Billable,
+		Project:        "work",
+		Tags:           []string{"docs", "writing"},
+		Start:          time.Now().Truncate(time.Second),
+		End:            time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+	if err := dal.CreateTask(context.Background(), task); err != nil {
+		t.Fatalf("CreateTask() returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := dal.Export(context.Background(), &buf, FormatJSON, models.TaskFilter{}); err != nil {
+		t.Fatalf("Export() returned error: %v", err)
+	}
+
+	importDBPath := filepath.Join(t.TempDir(), "imported.db")
+	importDAL, err := NewWorkDAL(importDBPath)
+	if err != nil {
+		t.Fatalf("NewWorkDAL() returned error: %v", err)
+	}
+
+	report, err := importDAL.Import(context.Background(), &buf, FormatJSON, models.ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import() returned error: %v", err)
+	}
+	if report.Inserted != 1 || report.Skipped != 0 || report.Errored != 0 {
+		t.Fatalf("Import() report = %+v, want 1 inserted", report)
+	}
+
+	tasks, err := importDAL.ListTasks(context.Background(), 0, 0)
+	if err != nil {
+		t.Fatalf("ListTasks() returned error: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Description != "write docs" || tasks[0].Project != "work" {
+		t.Fatalf("ListTasks() = %+v, want the imported task", tasks)
+	}
+
+	// Re-importing the same data should be skipped as a duplicate.
+	var buf2 bytes.Buffer
+	if err := dal.Export(context.Background(), &buf2, FormatJSON, models.TaskFilter{}); err != nil {
+		t.Fatalf("Export() returned error: %v", err)
+	}
+	report, err = importDAL.Import(context.Background(), &buf2, FormatJSON, models.ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import() returned error: %v", err)
+	}
+	if report.Inserted != 0 || report.Skipped != 1 {
+		t.Fatalf("re-Import() report = %+v, want 1 skipped", report)
+	}
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// migrateTimestampsToUnixSeconds reparses the task and shift start/end
+// columns from time.UnixDate strings into integer Unix seconds, with an
+// open (unfinished) task or shift represented by a NULL end rather than a
+// sentinel value. SQLite's ALTER TABLE can't reinterpret column contents,
+// so the rewrite happens here in Go.
+func migrateTimestampsToUnixSeconds(ctx context.Context, tx *sql.Tx) error {
+	for _, table := range []string{"task", "shift"} {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN start_ts INTEGER`, table)); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN end_ts INTEGER`, table)); err != nil {
+			return err
+		}
+		if err := backfillUnixTimestamps(ctx, tx, table); err != nil {
+			return err
+		}
+		for _, stmt := range []string{
+			fmt.Sprintf(`ALTER TABLE %s DROP COLUMN start`, table),
+			fmt.Sprintf(`ALTER TABLE %s DROP COLUMN end`, table),
+			fmt.Sprintf(`ALTER TABLE %s RENAME COLUMN start_ts TO start`, table),
+			fmt.Sprintf(`ALTER TABLE %s RENAME COLUMN end_ts TO end`, table),
+		} {
+			if _, err := tx.ExecContext(ctx, stmt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func backfillUnixTimestamps(ctx context.Context, tx *sql.Tx, table string) error {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`SELECT id, start, end FROM %s`, table))
+	if err != nil {
+		return err
+	}
+
+	type legacyRow struct {
+		id         int
+		start, end string
+	}
+	var legacyRows []legacyRow
+	for rows.Next() {
+		var r legacyRow
+		if err := rows.Scan(&r.id, &r.start, &r.end); err != nil {
+			rows.Close()
+			return err
+		}
+		legacyRows = append(legacyRows, r)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	for _, r := range legacyRows {
+		start, err := time.Parse(time.UnixDate, r.start)
+		if err != nil {
+			return fmt.Errorf("failed to parse start time %q for %s %d: %v", r.start, table, r.id, err)
+		}
+
+		var endTS interface{}
+		end, err := time.Parse(time.UnixDate, r.end)
+		if err != nil {
+			return fmt.Errorf("failed to parse end time %q for %s %d: %v", r.end, table, r.id, err)
+		}
+		if !end.IsZero() {
+			endTS = end.Unix()
+		}
+
+		if _, err := tx.ExecContext(
+			ctx,
+			fmt.Sprintf(`UPDATE %s SET start_ts = ?, end_ts = ? WHERE id = ?`, table),
+			start.Unix(),
+			endTS,
+			r.id,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// openAtSchemaV2 creates a fresh database already migrated to schema
+// version 2 (legacy time.UnixDate-formatted start/end columns), so tests
+// can exercise the migration 3 backfill against representative legacy rows.
+func openAtSchemaV2(t *testing.T) *WorkDAL {
+	t.Helper()
+
+	dbPath := filepath.Join(t.TempDir(), "database.db")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("sql.Open() returned error: %v", err)
+	}
+
+	dal := &WorkDAL{db: db}
+	if _, err := dal.db.ExecContext(context.Background(), `CREATE TABLE IF NOT EXISTS schema_version (version INTEGER NOT NULL)`); err != nil {
+		t.Fatalf("failed to create schema_version table: %v", err)
+	}
+	for _, m := range migrations[:2] {
+		if err := dal.applyMigration(context.Background(), m); err != nil {
+			t.Fatalf("applyMigration(%d) returned error: %v", m.version, err)
+		}
+	}
+
+	return dal
+}
+
+func TestMigrateTimestampsToUnixSeconds(t *testing.T) {
+	dal := openAtSchemaV2(t)
+
+	start := time.Date(2025, time.January, 2, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2025, time.January, 2, 17, 0, 0, 0, time.UTC)
+	openStart := time.Date(2025, time.January, 3, 9, 0, 0, 0, time.UTC)
+
+	if _, err := dal.db.Exec(
+		`INSERT INTO task (id, description, classification, project, start, end) VALUES (?, ?, ?, ?, ?, ?)`,
+		1, "finished task", 0, "", start.Format(time.UnixDate), end.Format(time.UnixDate),
+	); err != nil {
+		t.Fatalf("failed to seed legacy task row: %v", err)
+	}
+	if _, err := dal.db.Exec(
+		`INSERT INTO task (id, description, classification, project, start, end) VALUES (?, ?, ?, ?, ?, ?)`,
+		2, "open task", 0, "", openStart.Format(time.UnixDate), time.Time{}.Format(time.UnixDate),
+	); err != nil {
+		t.Fatalf("failed to seed legacy open task row: %v", err)
+	}
+
+	if err := dal.Migrate(context.Background()); err != nil {
+		t.Fatalf("Migrate() returned error: %v", err)
+	}
+
+	tasks, err := dal.ListTasks(context.Background(), 0, 0)
+	if err != nil {
+		t.Fatalf("ListTasks() returned error: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("ListTasks() returned %d tasks, want 2", len(tasks))
+	}
+
+	var finished, open bool
+	for _, task := range tasks {
+		switch task.ID {
+		case 1:
+			finished = true
+			if !task.Start.Equal(start) || !task.End.Equal(end) {
+				t.Errorf("task 1 = {Start: %v, End: %v}, want {%v, %v}", task.Start, task.End, start, end)
+			}
+		case 2:
+			open = true
+			if !task.Start.Equal(openStart) {
+				t.Errorf("task 2 Start = %v, want %v", task.Start, openStart)
+			}
+			if !task.End.IsZero() {
+				t.Errorf("task 2 End = %v, want zero (still running)", task.End)
+			}
+		}
+	}
+	if !finished || !open {
+		t.Fatalf("expected both task 1 and task 2 in results, got %+v", tasks)
+	}
+}
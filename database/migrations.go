@@ -0,0 +1,104 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// migration is a single forward schema change, applied exactly once and
+// recorded in the schema_version table. Most migrations only need up; apply
+// is an escape hatch for migrations that must reshape existing data in Go
+// (e.g. reparsing a column's contents) rather than pure SQL.
+type migration struct {
+	version int
+	up      string
+	apply   func(ctx context.Context, tx *sql.Tx) error
+}
+
+// migrations is the ordered list of schema changes for the work database.
+// Entries must never be reordered or edited once released; add new ones to
+// the end instead.
+var migrations = []migration{
+	{
+		version: 1,
+		up: `CREATE TABLE IF NOT EXISTS shift (id INTEGER PRIMARY KEY, start TIME, end TIME);
+CREATE TABLE IF NOT EXISTS task (id INTEGER PRIMARY KEY, description TEXT, classification INT, start TIME, end TIME);`,
+	},
+	{
+		version: 2,
+		up: `ALTER TABLE task ADD COLUMN project TEXT NOT NULL DEFAULT '';
+CREATE TABLE IF NOT EXISTS task_tag (task_id INTEGER NOT NULL REFERENCES task(id), tag TEXT NOT NULL, PRIMARY KEY (task_id, tag));`,
+	},
+	{
+		version: 3,
+		apply:   migrateTimestampsToUnixSeconds,
+	},
+}
+
+// Migrate brings the database schema up to the latest known version,
+// applying any pending migrations in order inside a single transaction each.
+func (dal *WorkDAL) Migrate(ctx context.Context) error {
+	if _, err := dal.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_version (version INTEGER NOT NULL)`); err != nil {
+		return fmt.Errorf("failed to create schema_version table: %v", err)
+	}
+
+	current, err := dal.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+
+		if err := dal.applyMigration(ctx, m); err != nil {
+			return fmt.Errorf("failed to apply migration %d: %v", m.version, err)
+		}
+	}
+
+	return nil
+}
+
+func (dal *WorkDAL) applyMigration(ctx context.Context, m migration) error {
+	tx, err := dal.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if m.up != "" {
+		if _, err := tx.ExecContext(ctx, m.up); err != nil {
+			return err
+		}
+	}
+	if m.apply != nil {
+		if err := m.apply(ctx, tx); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_version`); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_version (version) VALUES (?)`, m.version); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SchemaVersion returns the highest migration version currently applied to
+// the database, or 0 if no migrations have run yet.
+func (dal *WorkDAL) SchemaVersion(ctx context.Context) (int, error) {
+	var version int
+	row := dal.db.QueryRowContext(ctx, `SELECT version FROM schema_version LIMIT 1`)
+	if err := row.Scan(&version); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return version, nil
+}
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "database.db")
+
+	dal, err := NewWorkDAL(dbPath)
+	if err != nil {
+		t.Fatalf("NewWorkDAL() returned error: %v", err)
+	}
+
+	version, err := dal.SchemaVersion(context.Background())
+	if err != nil {
+		t.Fatalf("SchemaVersion() returned error: %v", err)
+	}
+
+	latest := migrations[len(migrations)-1].version
+	if version != latest {
+		t.Fatalf("SchemaVersion() = %d, want %d", version, latest)
+	}
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "database.db")
+
+	dal, err := NewWorkDAL(dbPath)
+	if err != nil {
+		t.Fatalf("NewWorkDAL() returned error: %v", err)
+	}
+
+	if err := dal.Migrate(context.Background()); err != nil {
+		t.Fatalf("second Migrate() returned error: %v", err)
+	}
+}
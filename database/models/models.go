@@ -0,0 +1,156 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TaskClassification categorizes the kind of work a task represents.
+type TaskClassification int
+
+const (
+	Unclassified TaskClassification = iota
+	Billable
+	NonBillable
+)
+
+func (c TaskClassification) String() string {
+	switch c {
+	case Billable:
+		return "billable"
+	case NonBillable:
+		return "non-billable"
+	default:
+		return "unclassified"
+	}
+}
+
+// ParseTaskClassification parses the output of TaskClassification.String,
+// for reading classifications back in from CSV/JSON import files.
+func ParseTaskClassification(s string) (TaskClassification, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "billable":
+		return Billable, nil
+	case "non-billable":
+		return NonBillable, nil
+	case "unclassified", "":
+		return Unclassified, nil
+	default:
+		return 0, fmt.Errorf("unknown task classification %q", s)
+	}
+}
+
+// Task is a single unit of tracked work.
+type Task struct {
+	ID             int
+	Description    string
+	Classification TaskClassification
+	Project        string
+	Tags           []string
+	Start          time.Time
+	End            time.Time
+}
+
+// Shift represents a period of availability for work, independent of any
+// particular task.
+type Shift struct {
+	ID    int
+	Start time.Time
+	End   time.Time
+}
+
+// TaskFilter narrows a task query. Zero-valued fields are ignored, so the
+// zero TaskFilter matches every task.
+type TaskFilter struct {
+	// From and To bound the task's start time as a half-open range
+	// [From, To): From is inclusive, To is exclusive. A zero value leaves
+	// that side of the range open.
+	From time.Time
+	To   time.Time
+
+	// Classifications, if non-empty, restricts results to tasks with one
+	// of the listed classifications.
+	Classifications []TaskClassification
+
+	// DescriptionContains is matched as a case-insensitive substring
+	// against the task description.
+	DescriptionContains string
+
+	// Project matches the task's project exactly.
+	Project string
+
+	// Tags, if non-empty, restricts results to tasks carrying every
+	// listed tag.
+	Tags []string
+
+	// Ascending sorts by ID ascending when true. The default, false,
+	// matches the historical newest-first ordering.
+	Ascending bool
+
+	// Limit caps the number of rows returned. Zero means unlimited.
+	Limit int
+
+	// Cursor, if non-zero, resumes a previous query after this task ID,
+	// for simple keyset pagination.
+	Cursor int
+}
+
+// ShiftFilter narrows a shift query. Zero-valued fields are ignored, so the
+// zero ShiftFilter matches every shift.
+type ShiftFilter struct {
+	// From and To bound the shift's start time as a half-open range
+	// [From, To): From is inclusive, To is exclusive. A zero value leaves
+	// that side of the range open.
+	From time.Time
+	To   time.Time
+
+	// Ascending sorts by ID ascending when true. The default, false,
+	// matches the historical newest-first ordering.
+	Ascending bool
+	Limit     int
+	Cursor    int
+}
+
+// ImportOptions controls how WorkDAL.Import treats rows from an import
+// file.
+type ImportOptions struct {
+	// DryRun validates and de-duplicates rows without writing anything.
+	DryRun bool
+}
+
+// ImportReport summarizes the outcome of a WorkDAL.Import call.
+type ImportReport struct {
+	Inserted int
+	Skipped  int
+	Errored  int
+	Errors   []string
+}
+
+// DailyTotals is the total tracked time on a single calendar day
+// ("YYYY-MM-DD", in UTC).
+type DailyTotals struct {
+	Date    string
+	Seconds int64
+}
+
+// ClassificationTotals is the total tracked time for a single
+// classification.
+type ClassificationTotals struct {
+	Classification TaskClassification
+	Seconds        int64
+}
+
+// ProjectTotals is the total tracked time for a single project.
+type ProjectTotals struct {
+	Project string
+	Seconds int64
+}
+
+// DayTimesheet splits a single calendar day's tracked time into billable and
+// non-billable seconds.
+type DayTimesheet struct {
+	Date               string
+	BillableSeconds    int64
+	NonBillableSeconds int64
+}
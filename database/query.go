@@ -0,0 +1,226 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmelahman/work/database/models"
+)
+
+// QueryTasks runs a structured task query built from filter. Conditions are
+// composed with parameter placeholders, never string-interpolated, so the
+// result is safe against SQL injection regardless of what callers put in
+// filter.
+func (dal *WorkDAL) QueryTasks(ctx context.Context, filter models.TaskFilter) ([]models.Task, error) {
+	tasks := []models.Task{}
+
+	query := `SELECT id, description, classification, project, start, end FROM task`
+	conditions := []string{}
+	args := []interface{}{}
+
+	if !filter.From.IsZero() {
+		conditions = append(conditions, `start >= ?`)
+		args = append(args, filter.From.Unix())
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, `start < ?`)
+		args = append(args, filter.To.Unix())
+	}
+	if len(filter.Classifications) > 0 {
+		placeholders := make([]string, len(filter.Classifications))
+		for i, c := range filter.Classifications {
+			placeholders[i] = "?"
+			args = append(args, c)
+		}
+		conditions = append(conditions, fmt.Sprintf(`classification IN (%s)`, strings.Join(placeholders, ", ")))
+	}
+	if filter.DescriptionContains != "" {
+		conditions = append(conditions, `description LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+escapeLike(filter.DescriptionContains)+"%")
+	}
+	if filter.Project != "" {
+		conditions = append(conditions, `project = ?`)
+		args = append(args, filter.Project)
+	}
+	if filter.Cursor > 0 {
+		if filter.Ascending {
+			conditions = append(conditions, `id > ?`)
+		} else {
+			conditions = append(conditions, `id < ?`)
+		}
+		args = append(args, filter.Cursor)
+	}
+	for _, tag := range filter.Tags {
+		conditions = append(conditions, `id IN (SELECT task_id FROM task_tag WHERE tag = ?)`)
+		args = append(args, tag)
+	}
+
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	if filter.Ascending {
+		query += ` ORDER BY id ASC`
+	} else {
+		query += ` ORDER BY id DESC`
+	}
+
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := dal.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect every row and close the cursor before looking up tags below:
+	// with the connection pool capped at one connection (see NewWorkDAL),
+	// issuing tagsForTask's query while rows is still open would starve
+	// waiting for a connection this same cursor is holding.
+	for rows.Next() {
+		var (
+			id             int
+			description    string
+			classification models.TaskClassification
+			project        string
+			start          int64
+			end            sql.NullInt64
+		)
+		if err := rows.Scan(&id, &description, &classification, &project, &start, &end); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		tasks = append(tasks, models.Task{
+			ID:             id,
+			Description:    description,
+			Classification: classification,
+			Project:        project,
+			Start:          time.Unix(start, 0),
+			End:            unixOrZero(end),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	rows.Close()
+
+	for i, task := range tasks {
+		tags, err := dal.tagsForTask(ctx, task.ID)
+		if err != nil {
+			return nil, err
+		}
+		tasks[i].Tags = tags
+	}
+
+	return tasks, nil
+}
+
+// QueryShifts runs a structured shift query built from filter.
+func (dal *WorkDAL) QueryShifts(ctx context.Context, filter models.ShiftFilter) ([]models.Shift, error) {
+	shifts := []models.Shift{}
+
+	query := `SELECT id, start, end FROM shift`
+	conditions := []string{}
+	args := []interface{}{}
+
+	if !filter.From.IsZero() {
+		conditions = append(conditions, `start >= ?`)
+		args = append(args, filter.From.Unix())
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, `start < ?`)
+		args = append(args, filter.To.Unix())
+	}
+	if filter.Cursor > 0 {
+		if filter.Ascending {
+			conditions = append(conditions, `id > ?`)
+		} else {
+			conditions = append(conditions, `id < ?`)
+		}
+		args = append(args, filter.Cursor)
+	}
+
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	if filter.Ascending {
+		query += ` ORDER BY id ASC`
+	} else {
+		query += ` ORDER BY id DESC`
+	}
+
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := dal.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id    int
+			start int64
+			end   sql.NullInt64
+		)
+		if err := rows.Scan(&id, &start, &end); err != nil {
+			return nil, err
+		}
+		shifts = append(shifts, models.Shift{ID: id, Start: time.Unix(start, 0), End: unixOrZero(end)})
+	}
+	return shifts, nil
+}
+
+// unixOrZero converts a nullable Unix-seconds column into a time.Time,
+// returning the zero Time (rather than the Unix epoch) when the column is
+// NULL, so an open task or shift is still reported via End.IsZero().
+func unixOrZero(v sql.NullInt64) time.Time {
+	if !v.Valid {
+		return time.Time{}
+	}
+	return time.Unix(v.Int64, 0)
+}
+
+// unixOrNull is the inverse of unixOrZero: it encodes an open task or
+// shift's zero-valued End as a SQL NULL rather than the Unix epoch.
+func unixOrNull(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.Unix()
+}
+
+func (dal *WorkDAL) tagsForTask(ctx context.Context, taskID int) ([]string, error) {
+	rows, err := dal.db.QueryContext(ctx, `SELECT tag FROM task_tag WHERE task_id = ? ORDER BY tag`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// escapeLike escapes the special characters used by SQLite's LIKE operator
+// so substring filters behave as plain substring matches.
+func escapeLike(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(s)
+}
@@ -0,0 +1,45 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmelahman/work/database/models"
+)
+
+func TestQueryTasksFiltersByProjectAndTag(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "database.db")
+	dal, err := NewWorkDAL(dbPath)
+	if err != nil {
+		t.Fatalf("NewWorkDAL() returned error: %v", err)
+	}
+
+	now := time.Now()
+	tasks := []models.Task{
+		{ID: 1, Description: "write docs", Project: "work", Tags: []string{"docs"}, Start: now, End: now},
+		{ID: 2, Description: "fix bug", Project: "other", Tags: []string{"bugfix"}, Start: now, End: now},
+	}
+	for _, task := range tasks {
+		if err := dal.CreateTask(context.Background(), task); err != nil {
+			t.Fatalf("CreateTask() returned error: %v", err)
+		}
+	}
+
+	got, err := dal.QueryTasks(context.Background(), models.TaskFilter{Project: "work"})
+	if err != nil {
+		t.Fatalf("QueryTasks() returned error: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != 1 {
+		t.Fatalf("QueryTasks(Project: work) = %+v, want only task 1", got)
+	}
+
+	got, err = dal.QueryTasks(context.Background(), models.TaskFilter{Tags: []string{"bugfix"}})
+	if err != nil {
+		t.Fatalf("QueryTasks() returned error: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != 2 {
+		t.Fatalf("QueryTasks(Tags: [bugfix]) = %+v, want only task 2", got)
+	}
+}
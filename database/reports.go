@@ -0,0 +1,144 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmelahman/work/database/models"
+)
+
+// Only completed tasks contribute to reports: an open task's duration isn't
+// final yet, so including it would make totals change underfoot as time
+// passes without the user doing anything.
+const completedTaskFilter = `end IS NOT NULL AND start >= ? AND start < ?`
+
+// SummaryByDay returns total tracked seconds per calendar day (UTC) for
+// completed tasks starting in [from, to).
+func (dal *WorkDAL) SummaryByDay(ctx context.Context, from, to time.Time) ([]models.DailyTotals, error) {
+	rows, err := dal.db.QueryContext(
+		ctx,
+		`SELECT strftime('%Y-%m-%d', start, 'unixepoch') AS day, SUM(end - start)
+FROM task
+WHERE `+completedTaskFilter+`
+GROUP BY day
+ORDER BY day`,
+		from.Unix(), to.Unix(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var totals []models.DailyTotals
+	for rows.Next() {
+		var t models.DailyTotals
+		if err := rows.Scan(&t.Date, &t.Seconds); err != nil {
+			return nil, err
+		}
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
+
+// SummaryByClassification returns total tracked seconds per classification
+// for completed tasks starting in [from, to).
+func (dal *WorkDAL) SummaryByClassification(ctx context.Context, from, to time.Time) ([]models.ClassificationTotals, error) {
+	rows, err := dal.db.QueryContext(
+		ctx,
+		`SELECT classification, SUM(end - start)
+FROM task
+WHERE `+completedTaskFilter+`
+GROUP BY classification
+ORDER BY classification`,
+		from.Unix(), to.Unix(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var totals []models.ClassificationTotals
+	for rows.Next() {
+		var t models.ClassificationTotals
+		if err := rows.Scan(&t.Classification, &t.Seconds); err != nil {
+			return nil, err
+		}
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
+
+// SummaryByProject returns total tracked seconds per project for completed
+// tasks starting in [from, to).
+func (dal *WorkDAL) SummaryByProject(ctx context.Context, from, to time.Time) ([]models.ProjectTotals, error) {
+	rows, err := dal.db.QueryContext(
+		ctx,
+		`SELECT project, SUM(end - start)
+FROM task
+WHERE `+completedTaskFilter+`
+GROUP BY project
+ORDER BY project`,
+		from.Unix(), to.Unix(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var totals []models.ProjectTotals
+	for rows.Next() {
+		var t models.ProjectTotals
+		if err := rows.Scan(&t.Project, &t.Seconds); err != nil {
+			return nil, err
+		}
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
+
+// Timesheet returns per-day billable vs non-billable totals for the
+// Monday-to-Sunday week containing week.
+func (dal *WorkDAL) Timesheet(ctx context.Context, week time.Time) ([]models.DayTimesheet, error) {
+	from, to := weekBounds(week)
+
+	rows, err := dal.db.QueryContext(
+		ctx,
+		`SELECT
+  strftime('%Y-%m-%d', start, 'unixepoch') AS day,
+  SUM(CASE WHEN classification = ? THEN end - start ELSE 0 END),
+  SUM(CASE WHEN classification != ? THEN end - start ELSE 0 END)
+FROM task
+WHERE `+completedTaskFilter+`
+GROUP BY day
+ORDER BY day`,
+		models.Billable, models.Billable, from.Unix(), to.Unix(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sheet []models.DayTimesheet
+	for rows.Next() {
+		var t models.DayTimesheet
+		if err := rows.Scan(&t.Date, &t.BillableSeconds, &t.NonBillableSeconds); err != nil {
+			return nil, err
+		}
+		sheet = append(sheet, t)
+	}
+	return sheet, rows.Err()
+}
+
+// weekBounds returns the [Monday 00:00, next Monday 00:00) range, in UTC,
+// for the week containing t.
+func weekBounds(t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	// time.Weekday is Sunday=0, Monday=1, ...; treat Sunday as day 7 so the
+	// offset back to Monday is always 0-6.
+	weekday := int(t.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	monday := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -(weekday - 1))
+	return monday, monday.AddDate(0, 0, 7)
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmelahman/work/database/models"
+)
+
+func TestTimesheetSplitsBillableAndNonBillable(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "database.db")
+	dal, err := NewWorkDAL(dbPath)
+	if err != nil {
+		t.Fatalf("NewWorkDAL() returned error: %v", err)
+	}
+
+	ctx := context.Background()
+	monday := time.Date(2026, time.July, 27, 9, 0, 0, 0, time.UTC)
+
+	tasks := []models.Task{
+		{ID: 1, Description: "billable work", Classification: models.Billable, Start: monday, End: monday.Add(2 * time.Hour)},
+		{ID: 2, Description: "internal chore", Classification: models.NonBillable, Start: monday.Add(3 * time.Hour), End: monday.Add(4 * time.Hour)},
+	}
+	for _, task := range tasks {
+		if err := dal.CreateTask(ctx, task); err != nil {
+			t.Fatalf("CreateTask() returned error: %v", err)
+		}
+	}
+
+	sheet, err := dal.Timesheet(ctx, monday)
+	if err != nil {
+		t.Fatalf("Timesheet() returned error: %v", err)
+	}
+	if len(sheet) != 1 {
+		t.Fatalf("Timesheet() returned %d days, want 1", len(sheet))
+	}
+
+	day := sheet[0]
+	if day.BillableSeconds != 2*3600 {
+		t.Errorf("BillableSeconds = %d, want %d", day.BillableSeconds, 2*3600)
+	}
+	if day.NonBillableSeconds != 3600 {
+		t.Errorf("NonBillableSeconds = %d, want %d", day.NonBillableSeconds, 3600)
+	}
+}
+
+func TestSummaryByProject(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "database.db")
+	dal, err := NewWorkDAL(dbPath)
+	if err != nil {
+		t.Fatalf("NewWorkDAL() returned error: %v", err)
+	}
+
+	ctx := context.Background()
+	start := time.Date(2026, time.July, 1, 9, 0, 0, 0, time.UTC)
+
+	tasks := []models.Task{
+		{ID: 1, Description: "task a", Project: "alpha", Start: start, End: start.Add(time.Hour)},
+		{ID: 2, Description: "task b", Project: "alpha", Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour)},
+		{ID: 3, Description: "task c", Project: "beta", Start: start.Add(4 * time.Hour), End: start.Add(5 * time.Hour)},
+	}
+	for _, task := range tasks {
+		if err := dal.CreateTask(ctx, task); err != nil {
+			t.Fatalf("CreateTask() returned error: %v", err)
+		}
+	}
+
+	totals, err := dal.SummaryByProject(ctx, start.AddDate(0, 0, -1), start.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("SummaryByProject() returned error: %v", err)
+	}
+
+	want := map[string]int64{"alpha": 2 * 3600, "beta": 3600}
+	if len(totals) != len(want) {
+		t.Fatalf("SummaryByProject() returned %d rows, want %d", len(totals), len(want))
+	}
+	for _, total := range totals {
+		if total.Seconds != want[total.Project] {
+			t.Errorf("project %q = %d seconds, want %d", total.Project, total.Seconds, want[total.Project])
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmelahman/work/database/models"
+)
+
+func TestSwitchTaskEndsCurrentAndStartsNext(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "database.db")
+	dal, err := NewWorkDAL(dbPath)
+	if err != nil {
+		t.Fatalf("NewWorkDAL() returned error: %v", err)
+	}
+
+	ctx := context.Background()
+	first := models.Task{ID: 1, Description: "first", Start: time.Now()}
+	if err := dal.CreateTask(ctx, first); err != nil {
+		t.Fatalf("CreateTask() returned error: %v", err)
+	}
+
+	second := models.Task{ID: 2, Description: "second", Start: time.Now()}
+	if err := dal.SwitchTask(ctx, second); err != nil {
+		t.Fatalf("SwitchTask() returned error: %v", err)
+	}
+
+	tasks, err := dal.ListTasks(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("ListTasks() returned error: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("ListTasks() returned %d tasks, want 2", len(tasks))
+	}
+
+	for _, task := range tasks {
+		switch task.ID {
+		case 1:
+			if task.End.IsZero() {
+				t.Errorf("task 1 End is zero, want it closed by SwitchTask")
+			}
+		case 2:
+			if !task.End.IsZero() {
+				t.Errorf("task 2 End = %v, want zero (still running)", task.End)
+			}
+		}
+	}
+}
@@ -0,0 +1,7 @@
+package main
+
+import "github.com/jmelahman/work/cmd"
+
+func main() {
+	cmd.Execute()
+}